@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	gatewayruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	greeterpb "github.com/gitops-ci-cd/greeting-service/internal/genpb"
+)
+
+//go:embed openapi
+var openapiFS embed.FS
+
+// newGatewayServer builds an *http.Server that reverse-proxies JSON/REST
+// requests to the gRPC server listening on grpcEndpoint, and serves the
+// embedded OpenAPI spec and Swagger UI under /openapi/. Readiness is exposed
+// on the always-on metrics server (see newMetricsServer), not here, since
+// the gateway itself is optional. dialCreds are the credentials used to
+// dial grpcEndpoint; pass insecure.NewCredentials() when the gRPC server is
+// plaintext, or gatewayDialCreds(reloader) when it requires TLS.
+func newGatewayServer(ctx context.Context, httpPort, grpcEndpoint string, dialCreds credentials.TransportCredentials) (*http.Server, error) {
+	mux := gatewayruntime.NewServeMux()
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(dialCreds)}
+	if err := greeterpb.RegisterGreeterServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return nil, err
+	}
+
+	openapiRoot, err := fs.Sub(openapiFS, "openapi")
+	if err != nil {
+		return nil, err
+	}
+
+	root := http.NewServeMux()
+	root.Handle("/openapi/", http.StripPrefix("/openapi/", http.FileServer(http.FS(openapiRoot))))
+	root.Handle("/", mux)
+
+	return &http.Server{
+		Addr:    httpPort,
+		Handler: root,
+	}, nil
+}
+
+// serveHTTP starts srv on its own listener and blocks until ctx is done, at
+// which point it shuts the server down with a bounded timeout. ready is
+// closed once the listener is accepting connections, or immediately if the
+// listener fails to bind, so callers waiting on it are never stuck when
+// serveHTTP returns an early error.
+func serveHTTP(ctx context.Context, srv *http.Server, ready chan<- struct{}) error {
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		slog.Error("Failed to start HTTP listener", "addr", srv.Addr, "error", err)
+		close(ready)
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		close(ready)
+		slog.Info("HTTP server listening...", "addr", srv.Addr)
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Failed to shut down HTTP server cleanly", "addr", srv.Addr, "error", err)
+			return err
+		}
+		<-errCh
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -6,16 +6,39 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/gitops-ci-cd/greeting-service/internal/interceptors"
+	"github.com/gitops-ci-cd/greeting-service/internal/logpolicy"
 	"github.com/gitops-ci-cd/greeting-service/internal/services"
+	"github.com/gitops-ci-cd/greeting-service/internal/telemetry"
 )
 
+// serviceName identifies this binary to OpenTelemetry and build-info metrics.
+const serviceName = "greeting-service"
+
+// version and commit are overridden at build time via:
+//
+//	-ldflags "-X main.version=... -X main.commit=..."
+var (
+	version = "dev"
+	commit  = "none"
+)
+
+// defaultDrainSeconds approximates readinessFailureThreshold * periodSeconds
+// + a small delta for a typical Kubernetes readiness probe (3 * 10s + 5s),
+// giving kube-proxy time to notice NOT_SERVING and remove the pod from
+// endpoints before we stop accepting new work.
+const defaultDrainSeconds = 35
+
 func init() {
 	level := func() slog.Level {
 		switch os.Getenv("LOG_LEVEL") {
@@ -41,9 +64,24 @@ func main() {
 	if port == ":" {
 		port = ":50051"
 	}
+	httpPort := os.Getenv("HTTP_PORT")
+
+	metricsPort := os.Getenv("METRICS_PORT")
+	if metricsPort == "" {
+		metricsPort = ":9090"
+	}
+
+	drainSeconds := defaultDrainSeconds
+	if v := os.Getenv("SHUTDOWN_DRAIN_SECONDS"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			drainSeconds = parsed
+		} else {
+			slog.Warn("Invalid SHUTDOWN_DRAIN_SECONDS, using default", "value", v, "default", defaultDrainSeconds)
+		}
+	}
 
 	// Run the server
-	if err := run(port, services.Register); err != nil {
+	if err := run(port, httpPort, metricsPort, drainSeconds, services.Register); err != nil {
 		slog.Error("Server terminated", "error", err)
 		os.Exit(1)
 	} else {
@@ -51,8 +89,13 @@ func main() {
 	}
 }
 
-// run sets up and starts the gRPC server
-func run(port string, registerFunc func(*grpc.Server)) error {
+// run sets up and starts the gRPC server, a /metrics Prometheus endpoint on
+// metricsPort, and, when httpPort is non-empty, a companion HTTP gateway
+// that reverse-proxies REST/JSON to the gRPC server. On SIGTERM it runs a
+// two-phase shutdown: readiness flips to failing first so Kubernetes can
+// drain the pod from service endpoints, then after drainSeconds it stops
+// accepting new work.
+func run(port, httpPort, metricsPort string, drainSeconds int, registerFunc func(*grpc.Server)) error {
 	// Create a TCP listener
 	listener, err := net.Listen("tcp", port)
 	if err != nil {
@@ -61,75 +104,150 @@ func run(port string, registerFunc func(*grpc.Server)) error {
 	}
 	defer listener.Close()
 
+	rd := newReadiness()
+
+	shutdownTelemetry, err := telemetry.Init(context.Background(), serviceName)
+	if err != nil {
+		slog.Error("Failed to configure telemetry", "error", err)
+		return err
+	}
+	defer func() {
+		if err := shutdownTelemetry(context.Background()); err != nil {
+			slog.Error("Failed to shut down telemetry cleanly", "error", err)
+		}
+	}()
+
+	logPolicy, err := logpolicy.Load(os.Getenv("LOG_POLICY_FILE"))
+	if err != nil {
+		slog.Error("Failed to load log policy", "error", err)
+		return err
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptors.BuildInterceptors(logPolicy)...),
+		grpc.ChainStreamInterceptor(interceptors.BuildStreamInterceptors(logPolicy)...),
+	}
+
+	tlsCreds, certReloader, err := newServerCreds()
+	if err != nil {
+		slog.Error("Failed to configure TLS", "error", err)
+		return err
+	}
+	if tlsCreds != nil {
+		serverOpts = append(serverOpts, tlsCreds)
+	}
+
 	// Create a new gRPC server
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(loggingInterceptor),
-	)
+	server := grpc.NewServer(serverOpts...)
+	grpc_health_v1.RegisterHealthServer(server, rd.grpcHealth)
 
 	// Register services using the provided function
 	registerFunc(server)
 
-	// Handle graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	setupSignalHandler(cancel)
+	// shutdownCtx governs the server/listener lifetimes; it's cancelled once
+	// the drain period (if any) has elapsed, or immediately if a subsystem
+	// fails, in which case failErr records why.
+	shutdownCtx, cancelShutdown := context.WithCancel(context.Background())
+	defer cancelShutdown()
+
+	var failOnce sync.Once
+	var failErr error
+	fail := func(err error) {
+		failOnce.Do(func() { failErr = err })
+		cancelShutdown()
+	}
+
+	sigCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	var wg sync.WaitGroup
+	ready := make(chan struct{})
 
-	// Run the server in a goroutine to allow for graceful shutdown
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
+		close(ready)
 		slog.Info("Server listening...", "port", port)
 		if err := server.Serve(listener); err != nil {
 			slog.Error("Failed to serve", "error", err)
-			cancel()
+			fail(err)
 		}
 	}()
+	<-ready
+
+	if certReloader != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := certReloader.watch(shutdownCtx, nil); err != nil {
+				slog.Error("TLS cert watcher exited", "error", err)
+			}
+		}()
+	}
 
-	// Wait for termination signal
-	<-ctx.Done()
-	slog.Warn("Server shutting down gracefully...")
-	server.GracefulStop()
-
-	return nil
-}
-
-// loggingInterceptor logs all incoming gRPC requests
-func loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	start := time.Now()
+	if httpPort != "" {
+		dialCreds := credentials.TransportCredentials(insecure.NewCredentials())
+		if certReloader != nil {
+			dialCreds = gatewayDialCreds(certReloader)
+		}
 
-	if protoReq, ok := req.(proto.Message); ok {
-		// Serialize protobuf message to JSON for logging
-		reqJSON, err := protojson.Marshal(protoReq)
+		gatewayReady := make(chan struct{})
+		gatewaySrv, err := newGatewayServer(shutdownCtx, httpPort, port, dialCreds)
 		if err != nil {
-			slog.Debug("Failed to marshal request to JSON", "error", err)
-		} else {
-			slog.Debug("gRPC request received", "method", info.FullMethod, "request", reqJSON)
+			slog.Error("Failed to set up HTTP gateway", "error", err)
+			cancelShutdown()
+			return err
 		}
-	}
 
-	// Process the request
-	res, err := handler(ctx, req)
-	duration := time.Since(start)
-
-	fields := []any{
-		"method", info.FullMethod,
-		"duration", duration.String(),
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := serveHTTP(shutdownCtx, gatewaySrv, gatewayReady); err != nil {
+				slog.Error("HTTP gateway terminated", "error", err)
+				fail(err)
+			}
+		}()
+		<-gatewayReady
 	}
 
-	if err != nil {
-		fields = append(fields, "error", err)
+	metricsReady := make(chan struct{})
+	metricsSrv := newMetricsServer(metricsPort, rd)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := serveHTTP(shutdownCtx, metricsSrv, metricsReady); err != nil {
+			slog.Error("Metrics server terminated", "error", err)
+			fail(err)
+		}
+	}()
+	<-metricsReady
+
+	// Wait for either a termination signal (drain, then graceful stop) or a
+	// subsystem failing outright (stop immediately and report why), so a
+	// bind failure or a crashed listener can never hang run() forever.
+	select {
+	case <-sigCtx.Done():
+		drainAndStop(rd, drainSeconds, func() {
+			slog.Warn("Server shutting down gracefully...")
+			cancelShutdown()
+			server.GracefulStop()
+		})
+	case <-shutdownCtx.Done():
+		slog.Error("A subsystem failed; stopping immediately", "error", failErr)
+		server.GracefulStop()
 	}
+	wg.Wait()
 
-	slog.Info("Handled gRPC request", fields...)
-
-	return res, err
+	return failErr
 }
 
-// setupSignalHandler sets up a signal handler to cancel the provided context
-func setupSignalHandler(cancelFunc context.CancelFunc) {
-	go func() {
-		ch := make(chan os.Signal, 1)
-		signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
-		sig := <-ch
-		slog.Warn("Received termination signal", "signal", sig)
-		cancelFunc()
-	}()
+// drainAndStop implements the two-phase shutdown: flip readiness to failing,
+// sleep for drainSeconds so kube-proxy has time to remove the pod from
+// service endpoints, then invoke stop (which cancels shutdownCtx and calls
+// server.GracefulStop).
+func drainAndStop(rd *readiness, drainSeconds int, stop func()) {
+	slog.Warn("Received termination signal, failing readiness and draining", "drainSeconds", drainSeconds)
+	rd.setReady(false)
+	time.Sleep(time.Duration(drainSeconds) * time.Second)
+	stop()
 }
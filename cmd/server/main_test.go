@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestDrainAndStopFlipsReadinessBeforeStop(t *testing.T) {
+	rd := newReadiness()
+	if !rd.isReady() {
+		t.Fatal("expected readiness to start serving")
+	}
+
+	var readyAtStop bool
+	stopped := make(chan struct{})
+
+	drainAndStop(rd, 0, func() {
+		readyAtStop = rd.isReady()
+		close(stopped)
+	})
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("stop was never called")
+	}
+
+	if readyAtStop {
+		t.Error("expected readiness to already be false when stop is invoked")
+	}
+}
+
+// TestRunReturnsPromptlyWhenASubsystemFailsToBind guards against run()
+// hanging forever when a subsystem (here, the metrics server) fails to bind
+// its listener: the failure must cancel shutdownCtx and make run() return,
+// rather than leaving it stuck waiting on a signal that never arrives.
+func TestRunReturnsPromptlyWhenASubsystemFailsToBind(t *testing.T) {
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer occupied.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run(":0", "", occupied.Addr().String(), 0, func(*grpc.Server) {})
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected run to return an error when the metrics listener fails to bind")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("run did not return after the metrics listener failed to bind")
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// buildInfoGauge exposes the running binary's version/commit as labels on a
+// constant 1, the standard Prometheus build_info pattern.
+var buildInfoGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "greeting_service_build_info",
+	Help: "Build information about the running greeting-service binary.",
+}, []string{"version", "commit"})
+
+func init() {
+	grpc_prometheus.EnableHandlingTimeHistogram()
+	prometheus.MustRegister(buildInfoGauge)
+	buildInfoGauge.WithLabelValues(version, commit).Set(1)
+}
+
+// newMetricsServer builds the always-on HTTP server exposing /metrics
+// (including the gRPC server metrics registered via grpc_prometheus.Register
+// in services.Register) and /healthz/ready. Readiness is mounted here,
+// rather than on the optional HTTP gateway, so it's reachable regardless of
+// whether HTTP_PORT opts into the REST gateway.
+func newMetricsServer(metricsPort string, rd *readiness) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz/ready", rd.Handler())
+
+	return &http.Server{
+		Addr:    metricsPort,
+		Handler: mux,
+	}
+}
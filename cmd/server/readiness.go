@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// readiness tracks whether the server should currently be considered ready
+// to receive traffic. It backs both the /healthz/ready HTTP endpoint and the
+// grpc.health.v1 service, so kube-proxy and gRPC-aware clients drain in sync.
+type readiness struct {
+	mu    sync.RWMutex
+	ready bool
+
+	grpcHealth *health.Server
+}
+
+// newReadiness returns a readiness tracker that starts out serving, with its
+// grpc.health.v1 status mirrored for every service name in serviceNames (""
+// covers the overall server status).
+func newReadiness(serviceNames ...string) *readiness {
+	grpcHealth := health.NewServer()
+	r := &readiness{ready: true, grpcHealth: grpcHealth}
+	r.setReady(true, serviceNames...)
+	return r
+}
+
+// setReady flips the readiness state and updates the grpc.health.v1 status
+// for every given service name (plus the overall "" status).
+func (r *readiness) setReady(ready bool, serviceNames ...string) {
+	r.mu.Lock()
+	r.ready = ready
+	r.mu.Unlock()
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if !ready {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	r.grpcHealth.SetServingStatus("", status)
+	for _, name := range serviceNames {
+		r.grpcHealth.SetServingStatus(name, status)
+	}
+}
+
+func (r *readiness) isReady() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ready
+}
+
+// Handler serves /healthz/ready: 200 while ready, 503 once draining has
+// started.
+func (r *readiness) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if !r.isReady() {
+			http.Error(w, "draining", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// certReloader holds the current server certificate behind a mutex so
+// GetCertificate can hand out the latest one while fsnotify swaps it in the
+// background.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// watch reloads the certificate whenever anything changes in the
+// directories holding certFile or keyFile, and blocks until ctx is done.
+// Kubernetes projected volumes (and cert-manager) rotate certificates by
+// repointing a "..data" directory symlink rather than writing tls.crt /
+// tls.key themselves, so watch cannot filter on the leaf file names; any
+// event observed for the watched directories triggers a reload attempt.
+// ready, if non-nil, is closed once the watches are registered and rotations
+// occurring after that point are guaranteed to be observed.
+func (r *certReloader) watch(ctx context.Context, ready chan<- struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	dirs := map[string]struct{}{
+		filepath.Dir(r.certFile): {},
+		filepath.Dir(r.keyFile):  {},
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return err
+		}
+	}
+	if ready != nil {
+		close(ready)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				if err := watcher.Add(filepath.Dir(event.Name)); err != nil {
+					slog.Error("Failed to re-add TLS cert watch after rotation", "path", event.Name, "error", err)
+				}
+			}
+			if err := r.reload(); err != nil {
+				slog.Error("Failed to reload TLS certificate", "error", err)
+				continue
+			}
+			slog.Info("Reloaded TLS certificate", "cert_file", r.certFile)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Error("TLS cert watcher error", "error", err)
+		}
+	}
+}
+
+// newServerCreds builds grpc.Creds from TLS_CERT_FILE / TLS_KEY_FILE /
+// TLS_CLIENT_CA_FILE, returning (nil, nil, nil) when TLS_CERT_FILE is unset
+// so the server falls back to plaintext. The returned reloader's watch
+// method must be run for certificate rotation to take effect.
+func newServerCreds() (grpc.ServerOption, *certReloader, error) {
+	certFile := os.Getenv("TLS_CERT_FILE")
+	keyFile := os.Getenv("TLS_KEY_FILE")
+	if certFile == "" {
+		return nil, nil, nil
+	}
+	if keyFile == "" {
+		return nil, nil, errors.New("TLS_KEY_FILE must be set alongside TLS_CERT_FILE")
+	}
+
+	reloader, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if clientCAFile := os.Getenv("TLS_CLIENT_CA_FILE"); clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, nil, errors.New("no certificates found in TLS_CLIENT_CA_FILE")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), reloader, nil
+}
+
+// gatewayDialCreds builds TransportCredentials for the in-process gateway's
+// loopback dial to the gRPC server. The gateway dials a bare port rather
+// than a DNS name, so hostname-based chain verification doesn't apply here;
+// instead we pin the exact certificate the server is currently presenting,
+// re-checked against the reloader on every handshake so rotations are
+// picked up without restarting the gateway. If the server requires client
+// certificates (mTLS), the gateway presents the same certificate/key as its
+// own identity.
+func gatewayDialCreds(reloader *certReloader) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		InsecureSkipVerify: true, // verified in VerifyPeerCertificate below instead
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			current, err := reloader.GetCertificate(nil)
+			if err != nil {
+				return err
+			}
+			if len(rawCerts) == 0 || len(current.Certificate) == 0 || !bytes.Equal(rawCerts[0], current.Certificate[0]) {
+				return errors.New("server certificate presented to gateway does not match TLS_CERT_FILE")
+			}
+			return nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return reloader.GetCertificate(nil)
+		},
+	})
+}
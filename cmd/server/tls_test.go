@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeKeyPair generates a self-signed cert/key pair under dir, named
+// tls.crt and tls.key.
+func writeKeyPair(t *testing.T, dir string, serial int64) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "greeter-service"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "tls.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		t.Fatalf("write cert: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tls.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+}
+
+// TestCertReloaderWatchReloadsOnDataSymlinkRotation simulates the layout
+// Kubernetes projected volumes use: tls.crt and tls.key are symlinks into a
+// "..data" directory symlink, and rotation swaps "..data" to point at a new
+// versioned directory rather than touching tls.crt/tls.key directly.
+func TestCertReloaderWatchReloadsOnDataSymlinkRotation(t *testing.T) {
+	base := t.TempDir()
+
+	dataV1 := filepath.Join(base, "..data_v1")
+	if err := os.Mkdir(dataV1, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeKeyPair(t, dataV1, 1)
+
+	dataLink := filepath.Join(base, "..data")
+	if err := os.Symlink("..data_v1", dataLink); err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(base, "tls.crt")
+	keyFile := filepath.Join(base, "tls.key")
+	if err := os.Symlink(filepath.Join("..data", "tls.crt"), certFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("..data", "tls.key"), keyFile); err != nil {
+		t.Fatal(err)
+	}
+
+	reloader, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader: %v", err)
+	}
+	initial, err := reloader.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("GetCertificate: %v", err)
+	}
+	initialDER := initial.Certificate[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchReady := make(chan struct{})
+	watchErr := make(chan error, 1)
+	go func() { watchErr <- reloader.watch(ctx, watchReady) }()
+	<-watchReady
+
+	// Rotate: stage a new version directory, then atomically repoint
+	// "..data" at it, exactly as cert-manager / kubelet do.
+	dataV2 := filepath.Join(base, "..data_v2")
+	if err := os.Mkdir(dataV2, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeKeyPair(t, dataV2, 2)
+
+	tmpLink := filepath.Join(base, "..data_tmp")
+	if err := os.Symlink("..data_v2", tmpLink); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmpLink, dataLink); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.After(2 * time.Second)
+	for {
+		cert, err := reloader.GetCertificate(nil)
+		if err == nil && string(cert.Certificate[0]) != string(initialDER) {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("certificate was not reloaded after ..data symlink rotation")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-watchErr; err != nil {
+		t.Fatalf("watch returned error: %v", err)
+	}
+}
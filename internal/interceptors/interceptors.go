@@ -0,0 +1,183 @@
+// Package interceptors provides the standard gRPC server interceptor chain
+// shared across the greeter binary and any alternative entrypoints that want
+// the same panic-recovery, request-ID, tracing, and logging behavior.
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/google/uuid"
+
+	"github.com/gitops-ci-cd/greeting-service/internal/logpolicy"
+)
+
+// requestIDMetadataKey is the incoming/outgoing metadata key carrying the
+// request ID.
+const requestIDMetadataKey = "x-request-id"
+
+type requestIDCtxKey struct{}
+
+// requestsInFlight tracks how many gRPC requests are currently being
+// handled, labeled by method. grpc_prometheus only exposes counters and
+// latency histograms, not a point-in-time gauge, so InFlightInterceptor
+// maintains this one directly.
+var requestsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "grpc_server_requests_in_flight",
+	Help: "Number of gRPC requests currently being handled, labeled by method.",
+}, []string{"grpc_method"})
+
+func init() {
+	prometheus.MustRegister(requestsInFlight)
+}
+
+// BuildInterceptors assembles the unary interceptor chain used by the
+// server: panic recovery runs outermost so it can catch panics from every
+// interceptor below it, followed by Prometheus metrics (latency histograms
+// and the in-flight gauge), request-ID propagation, OpenTelemetry tracing,
+// and finally structured request/response logging (governed by policy),
+// which reports the request ID populated by the earlier links.
+func BuildInterceptors(policy *logpolicy.Policy) []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		RecoveryInterceptor,
+		grpc_prometheus.UnaryServerInterceptor,
+		InFlightInterceptor,
+		RequestIDInterceptor,
+		otelgrpc.UnaryServerInterceptor(),
+		NewLoggingInterceptor(policy),
+	}
+}
+
+// BuildStreamInterceptors mirrors BuildInterceptors for streaming RPCs:
+// recovery, Prometheus metrics, request-ID propagation, tracing, and
+// structured logging, in the same order.
+func BuildStreamInterceptors(policy *logpolicy.Policy) []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		RecoveryStreamInterceptor,
+		grpc_prometheus.StreamServerInterceptor,
+		InFlightStreamInterceptor,
+		RequestIDStreamInterceptor,
+		otelgrpc.StreamServerInterceptor(),
+		NewLoggingStreamInterceptor(policy),
+	}
+}
+
+// InFlightInterceptor increments requestsInFlight for info.FullMethod before
+// calling handler and decrements it once handler returns.
+func InFlightInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	gauge := requestsInFlight.WithLabelValues(info.FullMethod)
+	gauge.Inc()
+	defer gauge.Dec()
+	return handler(ctx, req)
+}
+
+// InFlightStreamInterceptor is the streaming counterpart of
+// InFlightInterceptor.
+func InFlightStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	gauge := requestsInFlight.WithLabelValues(info.FullMethod)
+	gauge.Inc()
+	defer gauge.Dec()
+	return handler(srv, ss)
+}
+
+// RecoveryInterceptor converts panics in the handler chain into a
+// codes.Internal error and logs the stack trace, rather than crashing the
+// process.
+func RecoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Recovered from panic in gRPC handler",
+				"method", info.FullMethod,
+				"panic", r,
+				"stack", string(debug.Stack()),
+			)
+			err = status.Errorf(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// RecoveryStreamInterceptor is the streaming counterpart of
+// RecoveryInterceptor.
+func RecoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Recovered from panic in gRPC stream handler",
+				"method", info.FullMethod,
+				"panic", r,
+				"stack", string(debug.Stack()),
+			)
+			err = status.Errorf(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// RequestIDInterceptor reads x-request-id from incoming metadata, generating
+// one if absent, and makes it available both on the context (for slog
+// attributes further down the chain) and on outgoing metadata so it
+// propagates to any downstream RPCs the handler makes.
+func RequestIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	requestID := requestIDFromIncoming(ctx)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	ctx = context.WithValue(ctx, requestIDCtxKey{}, requestID)
+	ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, requestID)
+
+	return handler(ctx, req)
+}
+
+// RequestID returns the request ID stashed in ctx by RequestIDInterceptor,
+// or "" if none is present.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// wrappedServerStream overrides Context so stream interceptors can inject
+// values (e.g. the request ID) into the context stream handlers observe via
+// ss.Context().
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedServerStream) Context() context.Context { return w.ctx }
+
+// RequestIDStreamInterceptor is the streaming counterpart of
+// RequestIDInterceptor.
+func RequestIDStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+	requestID := requestIDFromIncoming(ctx)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	ctx = context.WithValue(ctx, requestIDCtxKey{}, requestID)
+	ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, requestID)
+
+	return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+}
+
+func requestIDFromIncoming(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(requestIDMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
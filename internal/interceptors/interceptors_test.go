@@ -0,0 +1,126 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// fakeServerStream is a minimal grpc.ServerStream for exercising stream
+// interceptors without a real connection.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestRecoveryInterceptorConvertsPanicToInternal(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+
+	_, err := RecoveryInterceptor(context.Background(), nil, info, handler)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected codes.Internal, got %v", status.Code(err))
+	}
+}
+
+func TestRequestIDInterceptorGeneratesIDWhenAbsent(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	var seen string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = RequestID(ctx)
+		return nil, nil
+	}
+
+	if _, err := RequestIDInterceptor(context.Background(), nil, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen == "" {
+		t.Error("expected a generated request ID, got empty string")
+	}
+}
+
+func TestRequestIDInterceptorPropagatesIncomingID(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDMetadataKey, "from-caller"))
+
+	var seen string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = RequestID(ctx)
+		return nil, nil
+	}
+
+	if _, err := RequestIDInterceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen != "from-caller" {
+		t.Errorf("expected incoming request ID to be propagated, got %q", seen)
+	}
+}
+
+func TestInFlightInterceptorTracksGaugeAcrossHandler(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/InFlight"}
+	var duringHandler float64
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		duringHandler = testutil.ToFloat64(requestsInFlight.WithLabelValues(info.FullMethod))
+		return nil, nil
+	}
+
+	if _, err := InFlightInterceptor(context.Background(), nil, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if duringHandler != 1 {
+		t.Errorf("expected gauge to read 1 while handler was running, got %v", duringHandler)
+	}
+
+	after := testutil.ToFloat64(requestsInFlight.WithLabelValues(info.FullMethod))
+	if after != 0 {
+		t.Errorf("expected gauge to be back to 0 after handler returns, got %v", after)
+	}
+}
+
+func TestRequestIDStreamInterceptorGeneratesIDWhenAbsent(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+	ss := &fakeServerStream{ctx: context.Background()}
+
+	var seen string
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		seen = RequestID(stream.Context())
+		return nil
+	}
+
+	if err := RequestIDStreamInterceptor(nil, ss, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen == "" {
+		t.Error("expected a generated request ID, got empty string")
+	}
+}
+
+func TestRequestIDStreamInterceptorPropagatesIncomingID(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDMetadataKey, "from-caller"))
+	ss := &fakeServerStream{ctx: ctx}
+
+	var seen string
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		seen = RequestID(stream.Context())
+		return nil
+	}
+
+	if err := RequestIDStreamInterceptor(nil, ss, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen != "from-caller" {
+		t.Errorf("expected incoming request ID to be propagated, got %q", seen)
+	}
+}
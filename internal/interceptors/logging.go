@@ -0,0 +1,120 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gitops-ci-cd/greeting-service/internal/logpolicy"
+)
+
+const truncatedSuffix = "...(truncated)"
+
+// NewLoggingInterceptor returns an interceptor that logs every gRPC request
+// with structured fields (peer address, method, duration, request ID, and
+// the resulting status code), applying policy to decide whether the
+// request/response payload gets logged at all, which fields within it are
+// redacted, and how many bytes of it survive before truncation.
+func NewLoggingInterceptor(policy *logpolicy.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		methodPolicy := policy.ForMethod(info.FullMethod)
+
+		if protoReq, ok := req.(proto.Message); ok {
+			logPayload(info.FullMethod, "request", protoReq, methodPolicy)
+		}
+
+		res, err := handler(ctx, req)
+		duration := time.Since(start)
+		code := status.Code(err)
+
+		if protoRes, ok := res.(proto.Message); ok {
+			logPayload(info.FullMethod, "response", protoRes, methodPolicy)
+		}
+
+		fields := []any{
+			"method", info.FullMethod,
+			"duration", duration.String(),
+			"request_id", RequestID(ctx),
+			"code", code.String(),
+		}
+
+		if p, ok := peer.FromContext(ctx); ok {
+			fields = append(fields, "peer", p.Addr.String())
+		}
+
+		if err != nil {
+			fields = append(fields, "error", err)
+		}
+
+		slog.Info("Handled gRPC request", fields...)
+
+		return res, err
+	}
+}
+
+// NewLoggingStreamInterceptor is the streaming counterpart of
+// NewLoggingInterceptor. Streaming RPCs have no single request/response
+// message to marshal, so only method-level fields (duration, request ID,
+// peer, status code) are logged; per-message payload logging would need to
+// wrap SendMsg/RecvMsg and isn't implemented since the proto has no
+// streaming RPCs yet.
+func NewLoggingStreamInterceptor(policy *logpolicy.Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := ss.Context()
+
+		err := handler(srv, ss)
+		duration := time.Since(start)
+		code := status.Code(err)
+
+		fields := []any{
+			"method", info.FullMethod,
+			"duration", duration.String(),
+			"request_id", RequestID(ctx),
+			"code", code.String(),
+		}
+
+		if p, ok := peer.FromContext(ctx); ok {
+			fields = append(fields, "peer", p.Addr.String())
+		}
+
+		if err != nil {
+			fields = append(fields, "error", err)
+		}
+
+		slog.Info("Handled gRPC stream", fields...)
+
+		return err
+	}
+}
+
+// logPayload marshals msg to JSON at DEBUG level, honoring the method's
+// sensitivity, redaction, and truncation settings.
+func logPayload(fullMethod, label string, msg proto.Message, mp logpolicy.MethodPolicy) {
+	if mp.Sensitive {
+		return
+	}
+
+	if len(mp.RedactFields) > 0 {
+		msg = logpolicy.Redact(msg, mp.RedactFields)
+	}
+
+	payload, err := protojson.Marshal(msg)
+	if err != nil {
+		slog.Debug("Failed to marshal payload to JSON", "method", fullMethod, "field", label, "error", err)
+		return
+	}
+
+	if mp.MaxPayloadBytes > 0 && len(payload) > mp.MaxPayloadBytes {
+		payload = append(payload[:mp.MaxPayloadBytes], []byte(truncatedSuffix)...)
+	}
+
+	slog.Debug("gRPC payload", "method", fullMethod, "field", label, "payload", string(payload))
+}
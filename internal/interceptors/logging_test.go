@@ -0,0 +1,137 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/gitops-ci-cd/greeting-service/internal/logpolicy"
+)
+
+// captureHandler records every logged attribute so tests can assert on
+// payload content without parsing text/JSON log output.
+type captureHandler struct{ records []slog.Record }
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *captureHandler) WithGroup(string) slog.Handler      { return h }
+
+func (h *captureHandler) payload() (string, bool) {
+	for _, r := range h.records {
+		var found string
+		var ok bool
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "payload" {
+				found = a.Value.String()
+				ok = true
+				return false
+			}
+			return true
+		})
+		if ok {
+			return found, true
+		}
+	}
+	return "", false
+}
+
+func withCapture(t *testing.T) *captureHandler {
+	t.Helper()
+	h := &captureHandler{}
+	prev := slog.Default()
+	slog.SetDefault(slog.New(h))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return h
+}
+
+func TestLoggingInterceptorSkipsSensitiveMethods(t *testing.T) {
+	capture := withCapture(t)
+	policy := &logpolicy.Policy{
+		Methods: map[string]logpolicy.MethodPolicy{
+			"/test.Service/Sensitive": {Sensitive: true},
+		},
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Sensitive"}
+	req, _ := structpb.NewValue("super-secret")
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	if _, err := NewLoggingInterceptor(policy)(context.Background(), req, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if payload, ok := capture.payload(); ok {
+		t.Errorf("expected no payload to be logged for a sensitive method, got %q", payload)
+	}
+}
+
+func TestLoggingStreamInterceptorReportsRequestIDAndCode(t *testing.T) {
+	capture := withCapture(t)
+	policy := &logpolicy.Policy{}
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+	ctx := context.WithValue(context.Background(), requestIDCtxKey{}, "stream-request-id")
+	ss := &fakeServerStream{ctx: ctx}
+
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+
+	if err := NewLoggingStreamInterceptor(policy)(nil, ss, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var requestID, code string
+	for _, r := range capture.records {
+		r.Attrs(func(a slog.Attr) bool {
+			switch a.Key {
+			case "request_id":
+				requestID = a.Value.String()
+			case "code":
+				code = a.Value.String()
+			}
+			return true
+		})
+	}
+	if requestID != "stream-request-id" {
+		t.Errorf("expected request_id %q, got %q", "stream-request-id", requestID)
+	}
+	if code != "OK" {
+		t.Errorf("expected code %q, got %q", "OK", code)
+	}
+}
+
+func TestLoggingInterceptorTruncatesOversizedPayload(t *testing.T) {
+	capture := withCapture(t)
+	policy := &logpolicy.Policy{DefaultMaxPayloadBytes: 4}
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+	req, _ := structpb.NewValue("a very very long string value")
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	if _, err := NewLoggingInterceptor(policy)(context.Background(), req, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload, ok := capture.payload()
+	if !ok {
+		t.Fatal("expected a payload to be logged")
+	}
+	if !strings.HasSuffix(payload, truncatedSuffix) {
+		t.Errorf("expected payload to end with %q, got %q", truncatedSuffix, payload)
+	}
+	if len(payload) != 4+len(truncatedSuffix) {
+		t.Errorf("expected truncated payload length %d, got %d", 4+len(truncatedSuffix), len(payload))
+	}
+}
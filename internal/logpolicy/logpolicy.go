@@ -0,0 +1,75 @@
+// Package logpolicy configures which gRPC request/response payloads get
+// logged, how large they're allowed to get, and which fields within them
+// must be redacted before anything is written out.
+package logpolicy
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultMaxPayloadBytes caps a logged payload when neither the method's
+// MaxPayloadBytes nor the Policy's DefaultMaxPayloadBytes is set.
+const defaultMaxPayloadBytes = 4096
+
+// MethodPolicy is the logging policy for a single fully-qualified gRPC
+// method (e.g. "/greeting.v1.GreeterService/Greet").
+type MethodPolicy struct {
+	// Sensitive methods are never payload-logged, only their method name,
+	// status code, and duration.
+	Sensitive bool `yaml:"sensitive" json:"sensitive"`
+	// MaxPayloadBytes truncates the marshaled payload beyond this size. Zero
+	// means "use the policy-wide default".
+	MaxPayloadBytes int `yaml:"maxPayloadBytes" json:"maxPayloadBytes"`
+	// RedactFields lists dot-separated protobuf field paths (e.g.
+	// "user.email") to zero out before marshaling. A oneof is crossed by
+	// naming the member field itself; the path only applies while that
+	// member is the one currently set.
+	RedactFields []string `yaml:"redactFields" json:"redactFields"`
+}
+
+// Policy is the full per-method logging policy, keyed by fully-qualified
+// gRPC method name.
+type Policy struct {
+	DefaultMaxPayloadBytes int                     `yaml:"defaultMaxPayloadBytes" json:"defaultMaxPayloadBytes"`
+	Methods                map[string]MethodPolicy `yaml:"methods" json:"methods"`
+}
+
+// Load reads a Policy from a YAML or JSON file (JSON is valid YAML, so one
+// parser handles both). An empty path yields an empty, permissive Policy
+// with no method-specific rules.
+func Load(path string) (*Policy, error) {
+	if path == "" {
+		return &Policy{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ForMethod returns the policy for fullMethod, falling back to the
+// policy-wide default payload cap when the method has no specific entry.
+func (p *Policy) ForMethod(fullMethod string) MethodPolicy {
+	if p == nil {
+		return MethodPolicy{MaxPayloadBytes: defaultMaxPayloadBytes}
+	}
+
+	mp := p.Methods[fullMethod]
+	if mp.MaxPayloadBytes == 0 {
+		if p.DefaultMaxPayloadBytes > 0 {
+			mp.MaxPayloadBytes = p.DefaultMaxPayloadBytes
+		} else {
+			mp.MaxPayloadBytes = defaultMaxPayloadBytes
+		}
+	}
+	return mp
+}
@@ -0,0 +1,87 @@
+package logpolicy
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRedactOneofMember(t *testing.T) {
+	v, err := structpb.NewValue("secret")
+	if err != nil {
+		t.Fatalf("NewValue: %v", err)
+	}
+
+	redacted := Redact(v, []string{"string_value"}).(*structpb.Value)
+
+	if redacted.GetKind() != nil {
+		t.Errorf("expected oneof to be cleared, got %v", redacted.GetKind())
+	}
+	if v.GetStringValue() != "secret" {
+		t.Error("Redact must not mutate the original message")
+	}
+}
+
+func TestRedactNestedMapEntry(t *testing.T) {
+	s, err := structpb.NewStruct(map[string]any{
+		"email": "user@example.com",
+		"name":  "Ada",
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	redacted := Redact(s, []string{"fields.email"}).(*structpb.Struct)
+
+	if _, ok := redacted.Fields["email"]; ok {
+		t.Error("expected \"email\" entry to be removed")
+	}
+	if _, ok := redacted.Fields["name"]; !ok {
+		t.Error("expected unrelated \"name\" entry to survive redaction")
+	}
+	if _, ok := s.Fields["email"]; !ok {
+		t.Error("Redact must not mutate the original message")
+	}
+}
+
+func TestRedactDeeplyNestedLeafThroughOneofAndMap(t *testing.T) {
+	inner, err := structpb.NewStruct(map[string]any{
+		"secret": "topsecret",
+		"public": "fine",
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+	outer, err := structpb.NewStruct(map[string]any{
+		"level1": inner.AsMap(),
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	redacted := Redact(outer, []string{"fields.level1.struct_value.fields.secret"}).(*structpb.Struct)
+
+	innerRedacted := redacted.Fields["level1"].GetStructValue()
+	if _, ok := innerRedacted.Fields["secret"]; ok {
+		t.Error("expected nested \"secret\" entry to be removed")
+	}
+	if _, ok := innerRedacted.Fields["public"]; !ok {
+		t.Error("expected unrelated nested \"public\" entry to survive redaction")
+	}
+}
+
+func TestPolicyForMethodFallsBackToDefault(t *testing.T) {
+	p := &Policy{
+		DefaultMaxPayloadBytes: 100,
+		Methods: map[string]MethodPolicy{
+			"/svc/Sensitive": {Sensitive: true},
+		},
+	}
+
+	if got := p.ForMethod("/svc/Sensitive"); !got.Sensitive || got.MaxPayloadBytes != 100 {
+		t.Errorf("unexpected method policy: %+v", got)
+	}
+	if got := p.ForMethod("/svc/Unknown"); got.Sensitive || got.MaxPayloadBytes != 100 {
+		t.Errorf("unexpected fallback policy: %+v", got)
+	}
+}
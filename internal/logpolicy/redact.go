@@ -0,0 +1,82 @@
+package logpolicy
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redact returns a clone of msg with every dot-separated field path in
+// fieldPaths cleared. A path segment names a message field; a segment
+// naming a oneof member clears that member specifically, leaving the rest
+// of the oneof untouched. A segment naming a map field must be followed by
+// a literal map key, e.g. "fields.email" clears the "email" entry of a
+// google.protobuf.Struct-shaped "fields" map; further segments after the
+// key descend into that entry's message. Unknown or not-currently-set path
+// segments are silently ignored, since a policy is shared across every
+// message a method might send.
+func Redact(msg proto.Message, fieldPaths []string) proto.Message {
+	if len(fieldPaths) == 0 {
+		return msg
+	}
+
+	clone := proto.Clone(msg)
+	for _, path := range fieldPaths {
+		redactPath(clone.ProtoReflect(), strings.Split(path, "."))
+	}
+	return clone
+}
+
+// redactPath walks segments against m, clearing (or descending into) the
+// field named by the first segment.
+func redactPath(m protoreflect.Message, segments []string) {
+	if !m.IsValid() || len(segments) == 0 {
+		return
+	}
+
+	field := m.Descriptor().Fields().ByName(protoreflect.Name(segments[0]))
+	if field == nil {
+		return
+	}
+
+	if field.IsMap() {
+		redactMapEntry(m, field, segments[1:])
+		return
+	}
+
+	if len(segments) == 1 {
+		m.Clear(field)
+		return
+	}
+
+	if field.Message() == nil || !m.Has(field) {
+		return
+	}
+	redactPath(m.Get(field).Message(), segments[1:])
+}
+
+// redactMapEntry clears (or descends into) the map entry keyed by
+// segments[0]. segments must be non-empty; a bare "mapField" path with no
+// key is a no-op.
+func redactMapEntry(m protoreflect.Message, mapField protoreflect.FieldDescriptor, segments []string) {
+	if len(segments) == 0 || !m.Has(mapField) {
+		return
+	}
+
+	mapVal := m.Mutable(mapField).Map()
+	key := protoreflect.ValueOfString(segments[0]).MapKey()
+	if !mapVal.Has(key) {
+		return
+	}
+
+	if len(segments) == 1 {
+		mapVal.Clear(key)
+		return
+	}
+
+	if mapField.MapValue().Message() == nil {
+		return
+	}
+	redactPath(mapVal.Get(key).Message(), segments[1:])
+}
@@ -0,0 +1,31 @@
+// Package services wires the individual gRPC service implementations onto a
+// *grpc.Server.
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grpc-ecosystem/go-grpc-prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/gitops-ci-cd/greeting-service/internal/genpb"
+)
+
+// Register attaches every service implementation to server, then calls
+// grpc_prometheus.Register last, after all services are registered, so it
+// can walk the server's full service descriptor and pre-initialize each
+// method's metrics to zero.
+func Register(server *grpc.Server) {
+	genpb.RegisterGreeterServiceServer(server, &greeterServer{})
+
+	grpc_prometheus.Register(server)
+}
+
+type greeterServer struct {
+	genpb.UnimplementedGreeterServiceServer
+}
+
+func (s *greeterServer) Greet(ctx context.Context, req *genpb.GreetRequest) (*genpb.GreetResponse, error) {
+	return &genpb.GreetResponse{Message: fmt.Sprintf("Hello, %s!", req.GetName())}, nil
+}